@@ -0,0 +1,37 @@
+// Package transport abstracts the JSON-RPC connection the mempool package
+// dials, so pkg/mempool works unmodified whether it's compiled as a native
+// binary (go-ethereum's rpc.Client, dialing a real OS socket) or as a
+// browser wasm build (a client speaking JSON-RPC over the DOM WebSocket
+// API, since real sockets don't exist inside a wasm sandbox). See
+// transport_notjs.go and transport_js.go for the two implementations.
+package transport
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Transport is the subset of JSON-RPC client behavior pkg/mempool needs:
+// issuing subscriptions, single and batched calls, and closing the
+// underlying connection.
+type Transport interface {
+	EthSubscribe(ctx context.Context, channel interface{}, args ...interface{}) (Subscription, error)
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
+	Close()
+}
+
+// Subscription mirrors the lifecycle surface of *rpc.ClientSubscription
+// that callers need in order to watch for drops and tear down cleanly.
+type Subscription interface {
+	Err() <-chan error
+	Unsubscribe()
+}
+
+// Dial opens a Transport to url. The concrete implementation is chosen at
+// compile time by GOOS/GOARCH: see dial in transport_notjs.go and
+// transport_js.go.
+func Dial(ctx context.Context, url string) (Transport, error) {
+	return dial(ctx, url)
+}
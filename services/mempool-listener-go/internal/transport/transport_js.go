@@ -0,0 +1,278 @@
+//go:build js && wasm
+
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"nhooyr.io/websocket"
+)
+
+// wsTransport talks JSON-RPC directly over a browser WebSocket via
+// nhooyr.io/websocket, whose js build shells out to the DOM WebSocket API.
+// go-ethereum's rpc.Client can't be used here: it dials real OS sockets,
+// which don't exist inside a wasm sandbox.
+type wsTransport struct {
+	conn *websocket.Conn
+
+	mu      sync.Mutex
+	nextID  uint64
+	pending map[uint64]chan rpcResponse
+	subs    map[string]*jsSubscription
+	closed  bool
+}
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcResponse struct {
+	ID     uint64          `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+type rpcNotification struct {
+	Method string `json:"method"`
+	Params struct {
+		Subscription string          `json:"subscription"`
+		Result       json.RawMessage `json:"result"`
+	} `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("transport: %s (code %d)", e.Message, e.Code)
+}
+
+// jsSubscription fans incoming eth_subscription notifications for one
+// server-assigned subscription id out to the caller's channel.
+type jsSubscription struct {
+	t       *wsTransport
+	id      string
+	ch      reflect.Value
+	elemTyp reflect.Type
+	errCh   chan error
+	once    sync.Once
+}
+
+func (s *jsSubscription) Err() <-chan error {
+	return s.errCh
+}
+
+func (s *jsSubscription) Unsubscribe() {
+	s.once.Do(func() {
+		s.t.removeSub(s.id)
+		var ok bool
+		_ = s.t.CallContext(context.Background(), &ok, "eth_unsubscribe", s.id)
+		close(s.errCh)
+	})
+}
+
+func dial(ctx context.Context, url string) (Transport, error) {
+	conn, _, err := websocket.Dial(ctx, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("transport: dial %s: %w", url, err)
+	}
+
+	t := &wsTransport{
+		conn:    conn,
+		pending: make(map[uint64]chan rpcResponse),
+		subs:    make(map[string]*jsSubscription),
+	}
+	go t.readLoop()
+	return t, nil
+}
+
+// readLoop reads frames for the lifetime of the connection, routing
+// eth_subscription notifications to their subscription and everything else
+// to the pending call awaiting that response id. It exits, failing every
+// outstanding call and subscription, once the connection drops.
+func (t *wsTransport) readLoop() {
+	ctx := context.Background()
+	for {
+		_, data, err := t.conn.Read(ctx)
+		if err != nil {
+			t.fail(err)
+			return
+		}
+
+		var probe struct {
+			ID     *uint64 `json:"id"`
+			Method string  `json:"method"`
+		}
+		if err := json.Unmarshal(data, &probe); err != nil {
+			continue
+		}
+
+		if probe.Method == "eth_subscription" {
+			var notif rpcNotification
+			if err := json.Unmarshal(data, &notif); err == nil {
+				t.dispatch(notif)
+			}
+			continue
+		}
+		if probe.ID != nil {
+			var resp rpcResponse
+			if err := json.Unmarshal(data, &resp); err == nil {
+				t.deliver(resp)
+			}
+		}
+	}
+}
+
+func (t *wsTransport) dispatch(notif rpcNotification) {
+	t.mu.Lock()
+	sub, ok := t.subs[notif.Params.Subscription]
+	t.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	val := reflect.New(sub.elemTyp)
+	if err := json.Unmarshal(notif.Params.Result, val.Interface()); err != nil {
+		return
+	}
+	sub.ch.Send(val.Elem())
+}
+
+func (t *wsTransport) deliver(resp rpcResponse) {
+	t.mu.Lock()
+	ch, ok := t.pending[resp.ID]
+	if ok {
+		delete(t.pending, resp.ID)
+	}
+	t.mu.Unlock()
+	if ok {
+		ch <- resp
+	}
+}
+
+// fail marks the connection dead, unblocking every pending call and
+// notifying every live subscription via its Err channel.
+func (t *wsTransport) fail(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return
+	}
+	t.closed = true
+	for _, ch := range t.pending {
+		close(ch)
+	}
+	t.pending = nil
+	for _, sub := range t.subs {
+		select {
+		case sub.errCh <- err:
+		default:
+		}
+	}
+}
+
+func (t *wsTransport) call(ctx context.Context, method string, params []interface{}) (rpcResponse, error) {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return rpcResponse{}, fmt.Errorf("transport: connection closed")
+	}
+	t.nextID++
+	id := t.nextID
+	respCh := make(chan rpcResponse, 1)
+	t.pending[id] = respCh
+	t.mu.Unlock()
+
+	body, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		return rpcResponse{}, fmt.Errorf("transport: marshaling request: %w", err)
+	}
+	if err := t.conn.Write(ctx, websocket.MessageText, body); err != nil {
+		return rpcResponse{}, fmt.Errorf("transport: writing request: %w", err)
+	}
+
+	select {
+	case resp, ok := <-respCh:
+		if !ok {
+			return rpcResponse{}, fmt.Errorf("transport: connection closed while awaiting response")
+		}
+		if resp.Error != nil {
+			return rpcResponse{}, resp.Error
+		}
+		return resp, nil
+	case <-ctx.Done():
+		return rpcResponse{}, ctx.Err()
+	}
+}
+
+func (t *wsTransport) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	resp, err := t.call(ctx, method, args)
+	if err != nil {
+		return err
+	}
+	if result == nil || resp.Result == nil {
+		return nil
+	}
+	return json.Unmarshal(resp.Result, result)
+}
+
+// BatchCallContext issues b sequentially over the same connection: unlike
+// go-ethereum's rpc.Client, there's no DOM WebSocket framing for a JSON-RPC
+// batch array, so there's no single-round-trip version of this to offer.
+func (t *wsTransport) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	for i := range b {
+		b[i].Error = t.CallContext(ctx, b[i].Result, b[i].Method, b[i].Args...)
+	}
+	return nil
+}
+
+func (t *wsTransport) EthSubscribe(ctx context.Context, channel interface{}, args ...interface{}) (Subscription, error) {
+	chVal := reflect.ValueOf(channel)
+	if chVal.Kind() != reflect.Chan {
+		return nil, fmt.Errorf("transport: EthSubscribe: channel must be a channel, got %T", channel)
+	}
+
+	resp, err := t.call(ctx, "eth_subscribe", args)
+	if err != nil {
+		return nil, err
+	}
+
+	var subID string
+	if err := json.Unmarshal(resp.Result, &subID); err != nil {
+		return nil, fmt.Errorf("transport: decoding subscription id: %w", err)
+	}
+
+	sub := &jsSubscription{
+		t:       t,
+		id:      subID,
+		ch:      chVal,
+		elemTyp: chVal.Type().Elem(),
+		errCh:   make(chan error, 1),
+	}
+
+	t.mu.Lock()
+	t.subs[subID] = sub
+	t.mu.Unlock()
+
+	return sub, nil
+}
+
+func (t *wsTransport) removeSub(id string) {
+	t.mu.Lock()
+	delete(t.subs, id)
+	t.mu.Unlock()
+}
+
+func (t *wsTransport) Close() {
+	t.conn.Close(websocket.StatusNormalClosure, "")
+}
@@ -0,0 +1,38 @@
+//go:build !(js && wasm)
+
+package transport
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// rpcTransport adapts go-ethereum's *rpc.Client to Transport.
+type rpcTransport struct {
+	client *rpc.Client
+}
+
+func dial(ctx context.Context, url string) (Transport, error) {
+	client, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	return rpcTransport{client: client}, nil
+}
+
+func (t rpcTransport) EthSubscribe(ctx context.Context, channel interface{}, args ...interface{}) (Subscription, error) {
+	return t.client.EthSubscribe(ctx, channel, args...)
+}
+
+func (t rpcTransport) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	return t.client.CallContext(ctx, result, method, args...)
+}
+
+func (t rpcTransport) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return t.client.BatchCallContext(ctx, b)
+}
+
+func (t rpcTransport) Close() {
+	t.client.Close()
+}
@@ -1,40 +1,247 @@
+//go:build !(js && wasm)
+
 package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"mev-bot/mempool-listener/pkg/aggregator"
+	"mev-bot/mempool-listener/pkg/arbitrage"
+	"mev-bot/mempool-listener/pkg/swap"
 )
 
+// metricsAddr is where the aggregator's Prometheus /metrics endpoint is
+// served.
+const metricsAddr = ":2112"
+
+// arbHopLimit bounds how many pool hops the arbitrage scanner will follow
+// when looking for a negative cycle.
+const arbHopLimit = 4
+
 func main() {
-	client, err := rpc.DialContext(context.Background(), "ws://127.0.0.1:9650/ext/bc/C/ws")
+	ctx := context.Background()
+
+	agg, err := aggregator.NewAggregator(ctx, aggregator.Config{Endpoints: loadEndpoints()})
 	if err != nil {
-		log.Fatalf("Failed to connect to the WebSocket endpoint: %v", err)
+		log.Fatalf("Failed to start mempool aggregator: %v", err)
 	}
-	defer client.Close()
+	defer agg.Close()
 
-	fmt.Println("Successfully connected to the WebSocket endpoint.")
+	go func() {
+		http.Handle("/metrics", agg.MetricsHandler())
+		log.Printf("Serving metrics on %s/metrics", metricsAddr)
+		log.Println(http.ListenAndServe(metricsAddr, nil))
+	}()
 
-	ctx := context.Background()
-	ch := make(chan common.Hash)
+	fmt.Println("Subscribed to pending transactions across all endpoints. Waiting...")
 
-	sub, err := client.EthSubscribe(ctx, ch, "newPendingTransactions")
-	if err != nil {
-		log.Fatalf("Failed to subscribe to pending transactions: %v", err)
+	registry := swap.DefaultRegistry()
+	events := make(chan *swap.Event)
+	go printEvents(events)
+
+	scanner := newArbitrageScanner(agg)
+
+	for obs := range agg.Events() {
+		go handleTx(ctx, agg, registry, scanner, obs, events)
+	}
+}
+
+// newArbitrageScanner wires an arbitrage.Scanner up against the pools
+// configured via MEV_BOT_POOLS, reading from the endpoint named by
+// MEV_BOT_ARB_ENDPOINT (the first configured endpoint if unset). If no
+// pools are configured, it returns nil and handleTx skips arbitrage
+// scanning entirely.
+func newArbitrageScanner(agg *aggregator.Aggregator) *arbitrage.Scanner {
+	pools := loadPools()
+	if len(pools) == 0 {
+		log.Println("MEV_BOT_POOLS not set; arbitrage scanning disabled")
+		return nil
+	}
+
+	endpointName := os.Getenv("MEV_BOT_ARB_ENDPOINT")
+	if endpointName == "" {
+		endpointName = loadEndpoints()[0].Name
+	}
+	mgr, ok := agg.Manager(endpointName)
+	if !ok {
+		log.Fatalf("MEV_BOT_ARB_ENDPOINT: unknown endpoint %q", endpointName)
+	}
+
+	notionalIn, ok := new(big.Int).SetString(envOr("MEV_BOT_ARB_NOTIONAL_WEI", "1000000000000000000"), 10)
+	if !ok {
+		log.Fatalf("MEV_BOT_ARB_NOTIONAL_WEI: not a valid integer")
+	}
+
+	graph := arbitrage.NewGraph(pools)
+	return arbitrage.NewScanner(mgr, graph, arbHopLimit, notionalIn)
+}
+
+// poolConfig is the MEV_BOT_POOLS JSON shape: a list of UniswapV2-style
+// pair contracts to track, e.g.
+//
+//	[{"address":"0x...","token0":"0x...","token1":"0x...","dex":"trader-joe","feeBps":30}]
+type poolConfig struct {
+	Address string `json:"address"`
+	Token0  string `json:"token0"`
+	Token1  string `json:"token1"`
+	DEX     string `json:"dex"`
+	FeeBps  uint32 `json:"feeBps"`
+}
+
+// loadPools reads the pools the arbitrage scanner should track from the
+// MEV_BOT_POOLS environment variable (a JSON array, see poolConfig). If
+// unset, it returns an empty slice.
+func loadPools() []*arbitrage.Pool {
+	raw := os.Getenv("MEV_BOT_POOLS")
+	if raw == "" {
+		return nil
+	}
+
+	var configs []poolConfig
+	if err := json.Unmarshal([]byte(raw), &configs); err != nil {
+		log.Fatalf("MEV_BOT_POOLS: invalid JSON: %v", err)
 	}
-	defer sub.Unsubscribe()
 
-	fmt.Println("Subscribed to pending transactions. Waiting...")
+	pools := make([]*arbitrage.Pool, len(configs))
+	for i, c := range configs {
+		pools[i] = arbitrage.NewPool(
+			common.HexToAddress(c.Address),
+			common.HexToAddress(c.Token0),
+			common.HexToAddress(c.Token1),
+			c.DEX,
+			c.FeeBps,
+		)
+	}
+	return pools
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// loadEndpoints reads upstream node endpoints from MEV_BOT_ENDPOINTS, a
+// comma-separated list of name=url pairs (e.g.
+// "avax-1=ws://10.0.0.1:9650/ext/bc/C/ws,avax-2=ws://10.0.0.2:9650/ext/bc/C/ws").
+// If unset, it falls back to a single local Avalanche C-Chain node.
+func loadEndpoints() []aggregator.Endpoint {
+	raw := os.Getenv("MEV_BOT_ENDPOINTS")
+	if raw == "" {
+		return []aggregator.Endpoint{
+			{Name: "local", URL: "ws://127.0.0.1:9650/ext/bc/C/ws"},
+		}
+	}
 
-	for {
-		select {
-		case err := <-sub.Err():
-			log.Fatalf("Subscription error: %v", err)
-		case txHash := <-ch:
-			fmt.Printf("New pending transaction hash: %s\n", txHash.Hex())
+	var endpoints []aggregator.Endpoint
+	for _, pair := range strings.Split(raw, ",") {
+		name, url, ok := strings.Cut(pair, "=")
+		if !ok {
+			log.Fatalf("MEV_BOT_ENDPOINTS: malformed entry %q, expected name=url", pair)
 		}
+		endpoints = append(endpoints, aggregator.Endpoint{Name: name, URL: url})
+	}
+	return endpoints
+}
+
+// handleTx fetches and decodes a single pending transaction, dispatching a
+// swap.Event on events when it recognizes the tx as a DEX swap or a blob
+// transaction, then (for swaps) triggers a bounded arbitrage reevaluation
+// of the subgraph that swap just touched. Transactions matched by no
+// decoder are dropped silently; blob txs never are.
+func handleTx(ctx context.Context, agg *aggregator.Aggregator, registry *swap.Registry, scanner *arbitrage.Scanner, obs aggregator.Observation, events chan<- *swap.Event) {
+	// handleTx runs in its own goroutine with nothing above it on the call
+	// stack to catch a panic, so a single malformed tx (e.g. calldata a
+	// decoder mishandles) must not be allowed to take down the process.
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("handleTx %s: recovered from panic: %v", obs.Hash, r)
+		}
+	}()
+
+	tx, sender, err := fetchTransaction(ctx, agg, obs)
+	if err != nil {
+		log.Printf("fetch %s: %v", obs.Hash, err)
+		return
+	}
+
+	if tx.Type() == types.BlobTxType {
+		ev, err := swap.DecodeBlobTx(tx, sender)
+		if err != nil {
+			log.Printf("decode blob tx %s: %v", obs.Hash, err)
+			return
+		}
+		events <- ev
+		return
+	}
+
+	ev, ok, err := registry.Decode(tx, sender)
+	if err != nil {
+		log.Printf("decode tx %s: %v", obs.Hash, err)
+		return
+	}
+	if !ok {
+		return
+	}
+	events <- ev
+
+	if scanner == nil {
+		return
+	}
+	opps, err := scanner.OnSwap(ctx, ev)
+	if err != nil {
+		log.Printf("arbitrage scan for %s: %v", obs.Hash, err)
+		return
+	}
+	for _, opp := range opps {
+		log.Printf("arbitrage opportunity: %d hops, notionalIn=%s, profitWei=%s", len(opp.Route.Pools), opp.NotionalIn, opp.ProfitWei)
+	}
+}
+
+// fetchTransaction pulls the raw RLP envelope for obs.Hash from the
+// endpoint that first reported it via eth_getRawTransactionByHash, and
+// decodes it along with the sender recovered from its signature.
+func fetchTransaction(ctx context.Context, agg *aggregator.Aggregator, obs aggregator.Observation) (*types.Transaction, common.Address, error) {
+	var raw hexutil.Bytes
+	if err := agg.CallContext(ctx, obs.Endpoint, &raw, "eth_getRawTransactionByHash", obs.Hash); err != nil {
+		return nil, common.Address{}, fmt.Errorf("eth_getRawTransactionByHash: %w", err)
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return nil, common.Address{}, fmt.Errorf("decoding tx envelope: %w", err)
+	}
+
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("recovering sender: %w", err)
+	}
+
+	return tx, sender, nil
+}
+
+func printEvents(events <-chan *swap.Event) {
+	for ev := range events {
+		fmt.Printf("[%s] tx=%s sender=%s in=%s out=%s amountIn=%s gasPrice=%s\n",
+			ev.Protocol, ev.TxHash.Hex(), ev.Sender.Hex(), ev.TokenIn.Hex(), ev.TokenOut.Hex(), bigIntOrZero(ev.AmountIn), bigIntOrZero(ev.GasPrice))
+	}
+}
+
+func bigIntOrZero(v *big.Int) string {
+	if v == nil {
+		return "0"
 	}
+	return v.String()
 }
@@ -0,0 +1,176 @@
+//go:build js && wasm
+
+// The wasm build embeds the listener directly in a web dashboard: it
+// exposes startListener(url) and onPendingTx(callback) as globals so a page
+// can stream decoded swap events without running any server component.
+package main
+
+import (
+	"context"
+	"log"
+	"math/big"
+	"sync"
+	"syscall/js"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"mev-bot/mempool-listener/pkg/mempool"
+	"mev-bot/mempool-listener/pkg/swap"
+)
+
+var (
+	registry = swap.DefaultRegistry()
+
+	callbacksMu sync.Mutex
+	callbacks   []js.Value
+)
+
+func main() {
+	js.Global().Set("startListener", js.FuncOf(startListener))
+	js.Global().Set("onPendingTx", js.FuncOf(onPendingTx))
+	select {} // keep the module alive so JS can keep calling into it
+}
+
+// startListener(url) dials url and streams pending transaction hashes,
+// decoding each into a swap.Event and dispatching it to every callback
+// registered via onPendingTx. It returns immediately; failures are logged
+// to the browser console rather than returned, since there's no caller
+// left on the stack by the time they happen.
+func startListener(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return js.ValueOf("startListener: expected a url argument")
+	}
+	url := args[0].String()
+
+	go func() {
+		ctx := context.Background()
+		mgr, err := mempool.NewSubscriptionManager(ctx, url)
+		if err != nil {
+			log.Printf("startListener: dial %s: %v", url, err)
+			return
+		}
+
+		hashes := make(chan common.Hash, 256)
+		if err := mgr.Subscribe(ctx, "newPendingTransactions", hashes); err != nil {
+			log.Printf("startListener: subscribe: %v", err)
+			return
+		}
+
+		for hash := range hashes {
+			go handleHash(ctx, mgr, hash)
+		}
+	}()
+
+	return js.Undefined()
+}
+
+// onPendingTx(callback) registers callback(event) to be invoked with a
+// plain JS object for every decoded swap event the listener emits.
+func onPendingTx(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 || args[0].Type() != js.TypeFunction {
+		return js.ValueOf("onPendingTx: expected a function argument")
+	}
+	callbacksMu.Lock()
+	callbacks = append(callbacks, args[0])
+	callbacksMu.Unlock()
+	return js.Undefined()
+}
+
+// handleHash fetches and decodes a single pending transaction and
+// dispatches it to every registered callback. Transactions matched by no
+// decoder are dropped silently; blob txs never are.
+func handleHash(ctx context.Context, mgr *mempool.SubscriptionManager, hash common.Hash) {
+	// handleHash runs in its own goroutine with nothing above it on the call
+	// stack to catch a panic, so a single malformed tx must not be allowed
+	// to take down the whole listener.
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("handleHash %s: recovered from panic: %v", hash, r)
+		}
+	}()
+
+	var raw hexutil.Bytes
+	if err := mgr.CallContext(ctx, &raw, "eth_getRawTransactionByHash", hash); err != nil {
+		log.Printf("fetch %s: %v", hash, err)
+		return
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		log.Printf("decode tx %s: %v", hash, err)
+		return
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		log.Printf("recover sender for %s: %v", hash, err)
+		return
+	}
+
+	if tx.Type() == types.BlobTxType {
+		ev, err := swap.DecodeBlobTx(tx, sender)
+		if err != nil {
+			log.Printf("decode blob tx %s: %v", hash, err)
+			return
+		}
+		dispatch(ev)
+		return
+	}
+
+	ev, ok, err := registry.Decode(tx, sender)
+	if err != nil {
+		log.Printf("decode tx %s: %v", hash, err)
+		return
+	}
+	if !ok {
+		return
+	}
+	dispatch(ev)
+}
+
+func dispatch(ev *swap.Event) {
+	jsEvent := eventToJS(ev)
+
+	callbacksMu.Lock()
+	cbs := append([]js.Value(nil), callbacks...)
+	callbacksMu.Unlock()
+
+	for _, cb := range cbs {
+		cb.Invoke(jsEvent)
+	}
+}
+
+// eventToJS converts ev into the plain JS object shape onPendingTx
+// callbacks receive; the big-integer fields (gasPrice, amountIn, amountOut)
+// are always passed as decimal strings, since they may not fit a JS number.
+func eventToJS(ev *swap.Event) js.Value {
+	path := make([]interface{}, len(ev.Path))
+	for i, addr := range ev.Path {
+		path[i] = addr.Hex()
+	}
+	blobHashes := make([]interface{}, len(ev.BlobVersionedHashes))
+	for i, h := range ev.BlobVersionedHashes {
+		blobHashes[i] = h.Hex()
+	}
+
+	return js.ValueOf(map[string]interface{}{
+		"txHash":              ev.TxHash.Hex(),
+		"protocol":            ev.Protocol,
+		"sender":              ev.Sender.Hex(),
+		"gasPrice":            bigIntString(ev.GasPrice),
+		"tokenIn":             ev.TokenIn.Hex(),
+		"tokenOut":            ev.TokenOut.Hex(),
+		"amountIn":            bigIntString(ev.AmountIn),
+		"amountOut":           bigIntString(ev.AmountOut),
+		"path":                path,
+		"blobVersionedHashes": blobHashes,
+	})
+}
+
+func bigIntString(v *big.Int) string {
+	if v == nil {
+		return "0"
+	}
+	return v.String()
+}
@@ -0,0 +1,149 @@
+// Package aggregator fans a pending-transaction subscription out across
+// several upstream nodes, merges them into a single deduplicated stream,
+// and tracks which endpoint tends to see transactions earliest.
+package aggregator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"mev-bot/mempool-listener/pkg/mempool"
+)
+
+const defaultDedupTTL = 2 * time.Minute
+
+// Endpoint is one upstream node to subscribe to.
+type Endpoint struct {
+	// Name labels this endpoint in metrics; it should be short and stable
+	// (e.g. "avax-1", "avax-2", "eth", "bsc"), not the raw URL.
+	Name string
+	URL  string
+}
+
+// Config configures an Aggregator.
+type Config struct {
+	Endpoints []Endpoint
+
+	// DedupTTL bounds how long a tx hash is remembered for first-seen
+	// comparisons across endpoints. Defaults to 2 minutes.
+	DedupTTL time.Duration
+}
+
+// Observation is one merged, deduplicated pending-tx sighting: the hash and
+// the endpoint that reported it first, which is also the endpoint a caller
+// should use to fetch the transaction's full details from.
+type Observation struct {
+	Hash     common.Hash
+	Endpoint string
+}
+
+// Aggregator subscribes to newPendingTransactions on every configured
+// endpoint and merges them into a single deduplicated channel.
+type Aggregator struct {
+	mgrs    map[string]*mempool.SubscriptionManager
+	dedup   *ttlDedup
+	metrics *metrics
+	out     chan Observation
+}
+
+// NewAggregator dials every endpoint in cfg and subscribes to pending
+// transactions on each. If any endpoint fails to dial or subscribe, the
+// endpoints already connected are torn down and an error is returned.
+func NewAggregator(ctx context.Context, cfg Config) (*Aggregator, error) {
+	if len(cfg.Endpoints) == 0 {
+		return nil, fmt.Errorf("aggregator: at least one endpoint is required")
+	}
+	ttl := cfg.DedupTTL
+	if ttl <= 0 {
+		ttl = defaultDedupTTL
+	}
+
+	a := &Aggregator{
+		mgrs:    make(map[string]*mempool.SubscriptionManager, len(cfg.Endpoints)),
+		dedup:   newTTLDedup(ttl),
+		metrics: newMetrics(),
+		out:     make(chan Observation, 256),
+	}
+
+	for _, ep := range cfg.Endpoints {
+		if _, dup := a.mgrs[ep.Name]; dup {
+			a.Close()
+			return nil, fmt.Errorf("aggregator: duplicate endpoint name %q", ep.Name)
+		}
+
+		mgr, err := mempool.NewSubscriptionManager(ctx, ep.URL)
+		if err != nil {
+			a.Close()
+			return nil, fmt.Errorf("aggregator: dial %s: %w", ep.Name, err)
+		}
+		a.mgrs[ep.Name] = mgr
+
+		ch := make(chan common.Hash, 256)
+		if err := mgr.Subscribe(ctx, "newPendingTransactions", ch); err != nil {
+			a.Close()
+			return nil, fmt.Errorf("aggregator: subscribe %s: %w", ep.Name, err)
+		}
+		go a.consume(ep.Name, ch)
+	}
+
+	return a, nil
+}
+
+// consume relays endpoint's pending-tx hashes into the merged output
+// stream, recording per-endpoint metrics and forwarding only the first
+// sighting of each hash.
+func (a *Aggregator) consume(endpoint string, ch <-chan common.Hash) {
+	for h := range ch {
+		a.metrics.seenTotal.WithLabelValues(endpoint).Inc()
+
+		delta, isFirst := a.dedup.observe(h, time.Now())
+		if isFirst {
+			a.metrics.firstSeenTotal.WithLabelValues(endpoint).Inc()
+			a.out <- Observation{Hash: h, Endpoint: endpoint}
+			continue
+		}
+		a.metrics.observationDelta.WithLabelValues(endpoint).Observe(delta.Seconds())
+	}
+}
+
+// Events returns the merged, deduplicated stream of pending tx sightings.
+func (a *Aggregator) Events() <-chan Observation {
+	return a.out
+}
+
+// CallContext performs a single RPC call against the named endpoint, e.g.
+// to fetch the full transaction behind an Observation's hash from the node
+// that actually reported it.
+func (a *Aggregator) CallContext(ctx context.Context, endpoint string, result interface{}, method string, args ...interface{}) error {
+	mgr, ok := a.mgrs[endpoint]
+	if !ok {
+		return fmt.Errorf("aggregator: unknown endpoint %q", endpoint)
+	}
+	return mgr.CallContext(ctx, result, method, args...)
+}
+
+// Manager returns the SubscriptionManager for a configured endpoint, for
+// callers (e.g. the arbitrage scanner) that need direct RPC access such as
+// batched eth_call rather than just the merged event stream.
+func (a *Aggregator) Manager(endpoint string) (*mempool.SubscriptionManager, bool) {
+	mgr, ok := a.mgrs[endpoint]
+	return mgr, ok
+}
+
+// MetricsHandler returns the HTTP handler to serve on /metrics.
+func (a *Aggregator) MetricsHandler() http.Handler {
+	return a.metrics.Handler()
+}
+
+// Close tears down every endpoint's subscription manager and stops the
+// dedup sweeper.
+func (a *Aggregator) Close() {
+	for _, mgr := range a.mgrs {
+		mgr.Close()
+	}
+	a.dedup.close()
+}
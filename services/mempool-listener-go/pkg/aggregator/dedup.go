@@ -0,0 +1,69 @@
+package aggregator
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ttlDedup tracks the first time each tx hash was observed, across all
+// endpoints, for a fixed retention window. It answers two questions: is
+// this the first endpoint to report h, and if not, how far behind the
+// first report was this one.
+type ttlDedup struct {
+	ttl time.Duration
+
+	mu     sync.Mutex
+	seenAt map[common.Hash]time.Time
+
+	stop chan struct{}
+}
+
+func newTTLDedup(ttl time.Duration) *ttlDedup {
+	d := &ttlDedup{
+		ttl:    ttl,
+		seenAt: make(map[common.Hash]time.Time),
+		stop:   make(chan struct{}),
+	}
+	go d.sweepLoop()
+	return d
+}
+
+// observe records that h was seen at now (unless it already has an earlier
+// record) and reports whether this call was the first to see it, plus the
+// delay behind the first sighting when it wasn't.
+func (d *ttlDedup) observe(h common.Hash, now time.Time) (delta time.Duration, isFirst bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if first, ok := d.seenAt[h]; ok {
+		return now.Sub(first), false
+	}
+	d.seenAt[h] = now
+	return 0, true
+}
+
+func (d *ttlDedup) sweepLoop() {
+	ticker := time.NewTicker(d.ttl)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			cutoff := now.Add(-d.ttl)
+			d.mu.Lock()
+			for h, t := range d.seenAt {
+				if t.Before(cutoff) {
+					delete(d.seenAt, h)
+				}
+			}
+			d.mu.Unlock()
+		case <-d.stop:
+			return
+		}
+	}
+}
+
+func (d *ttlDedup) close() {
+	close(d.stop)
+}
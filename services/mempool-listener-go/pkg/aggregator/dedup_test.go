@@ -0,0 +1,71 @@
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestTTLDedupObserveFirstAndSubsequent(t *testing.T) {
+	d := newTTLDedup(time.Hour)
+	defer d.close()
+
+	h := common.HexToHash("0x01")
+	t0 := time.Now()
+
+	delta, isFirst := d.observe(h, t0)
+	if !isFirst {
+		t.Fatal("first observe of a hash should report isFirst=true")
+	}
+	if delta != 0 {
+		t.Fatalf("first observe delta = %s, want 0", delta)
+	}
+
+	t1 := t0.Add(250 * time.Millisecond)
+	delta, isFirst = d.observe(h, t1)
+	if isFirst {
+		t.Fatal("second observe of the same hash should report isFirst=false")
+	}
+	if delta != 250*time.Millisecond {
+		t.Fatalf("delta = %s, want 250ms", delta)
+	}
+}
+
+func TestTTLDedupObserveDistinctHashesAreIndependent(t *testing.T) {
+	d := newTTLDedup(time.Hour)
+	defer d.close()
+
+	now := time.Now()
+	if _, isFirst := d.observe(common.HexToHash("0x01"), now); !isFirst {
+		t.Fatal("hash 0x01 should be new")
+	}
+	if _, isFirst := d.observe(common.HexToHash("0x02"), now); !isFirst {
+		t.Fatal("hash 0x02 should be new, independent of hash 0x01")
+	}
+}
+
+func TestTTLDedupSweepEvictsExpiredEntries(t *testing.T) {
+	const ttl = 20 * time.Millisecond
+	d := newTTLDedup(ttl)
+	defer d.close()
+
+	h := common.HexToHash("0x01")
+	if _, isFirst := d.observe(h, time.Now()); !isFirst {
+		t.Fatal("first observe should report isFirst=true")
+	}
+
+	// The sweep loop ticks every ttl and evicts entries older than ttl;
+	// poll until it has run rather than racing a single fixed sleep.
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		d.mu.Lock()
+		_, stillPresent := d.seenAt[h]
+		d.mu.Unlock()
+		if !stillPresent {
+			return
+		}
+		time.Sleep(ttl)
+	}
+	t.Fatal("expired entry was never swept")
+}
@@ -0,0 +1,44 @@
+package aggregator
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metrics holds the Prometheus collectors an Aggregator updates as it
+// observes pending transactions from each configured endpoint.
+type metrics struct {
+	registry *prometheus.Registry
+
+	seenTotal        *prometheus.CounterVec
+	firstSeenTotal   *prometheus.CounterVec
+	observationDelta *prometheus.HistogramVec
+}
+
+func newMetrics() *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		seenTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mempool_tx_seen_total",
+			Help: "Pending transactions observed per upstream endpoint, including duplicates already seen elsewhere.",
+		}, []string{"endpoint"}),
+		firstSeenTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mempool_tx_first_seen_total",
+			Help: "Pending transactions for which this endpoint was the first configured endpoint to report the hash.",
+		}, []string{"endpoint"}),
+		observationDelta: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "mempool_tx_observation_delta_seconds",
+			Help:    "Delay between the first endpoint to report a tx hash and every later endpoint that also reported it.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"endpoint"}),
+	}
+	m.registry.MustRegister(m.seenTotal, m.firstSeenTotal, m.observationDelta)
+	return m
+}
+
+// Handler returns the HTTP handler for the Aggregator's /metrics endpoint.
+func (m *metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
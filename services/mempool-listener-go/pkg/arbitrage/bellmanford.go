@@ -0,0 +1,149 @@
+package arbitrage
+
+import (
+	"math"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Route is a candidate arbitrage cycle: a sequence of pool hops that starts
+// and ends at the same token.
+type Route struct {
+	// Tokens is the cycle's token sequence, including the repeated start
+	// token at both ends: len(Tokens) == len(Pools)+1.
+	Tokens []common.Address
+	Pools  []*Pool
+	// LogProfit is the sum of -log(price) across the cycle. Negative means
+	// profitable (the product of per-hop prices, fees already folded in
+	// via Pool.PriceOut, exceeds 1).
+	LogProfit float64
+}
+
+// key identifies a route by its ordered pool addresses, for deduplicating
+// the same cycle found from two different starting tokens.
+func (r Route) key() string {
+	var b strings.Builder
+	for _, p := range r.Pools {
+		b.WriteString(p.Address.Hex())
+		b.WriteByte('|')
+	}
+	return b.String()
+}
+
+type edge struct {
+	to     common.Address
+	weight float64
+	pool   *Pool
+}
+
+type predecessor struct {
+	from common.Address
+	pool *Pool
+	hops int
+}
+
+// negativeCycles looks for a negative-weight cycle through source of at
+// most hopLimit edges in the -log(price) graph built from pools, using a
+// Bellman-Ford relaxation bounded to hopLimit iterations: after k
+// iterations, dist[v] holds the shortest path to v using at most k edges,
+// so if a profitable cycle of length <= hopLimit passes through source,
+// dist[source] goes negative by the final iteration.
+func negativeCycles(pools []*Pool, source common.Address, hopLimit int) []Route {
+	adj := make(map[common.Address][]edge)
+	nodes := map[common.Address]bool{source: true}
+	for _, p := range pools {
+		if price, ok := p.PriceOut(p.Token0, p.Token1); ok && price > 0 {
+			adj[p.Token0] = append(adj[p.Token0], edge{p.Token1, -math.Log(price), p})
+		}
+		if price, ok := p.PriceOut(p.Token1, p.Token0); ok && price > 0 {
+			adj[p.Token1] = append(adj[p.Token1], edge{p.Token0, -math.Log(price), p})
+		}
+		nodes[p.Token0] = true
+		nodes[p.Token1] = true
+	}
+
+	const inf = math.MaxFloat64
+	dist := make(map[common.Address]float64, len(nodes))
+	pred := make(map[common.Address]predecessor, len(nodes))
+	for n := range nodes {
+		dist[n] = inf
+	}
+	dist[source] = 0
+
+	for i := 0; i < hopLimit; i++ {
+		prev := make(map[common.Address]float64, len(dist))
+		for n, d := range dist {
+			prev[n] = d
+		}
+		for from, edges := range adj {
+			d := prev[from]
+			if d == inf {
+				continue
+			}
+			for _, e := range edges {
+				if nd := d + e.weight; nd < dist[e.to] {
+					dist[e.to] = nd
+					pred[e.to] = predecessor{from: from, pool: e.pool, hops: i + 1}
+				}
+			}
+		}
+	}
+
+	if dist[source] >= 0 {
+		return nil
+	}
+
+	route, ok := reconstructCycle(source, pred, hopLimit)
+	if !ok {
+		return nil
+	}
+	for i, p := range route.Pools {
+		price, _ := p.PriceOut(route.Tokens[i], route.Tokens[i+1])
+		route.LogProfit -= math.Log(price)
+	}
+	if route.LogProfit >= 0 {
+		return nil
+	}
+	return []Route{route}
+}
+
+// reconstructCycle walks pred backward from source until it returns to
+// source, yielding the cycle's tokens and pools in forward order.
+func reconstructCycle(source common.Address, pred map[common.Address]predecessor, hopLimit int) (Route, bool) {
+	var tokens []common.Address
+	var pools []*Pool
+
+	cur := source
+	visited := make(map[common.Address]bool)
+	for i := 0; i < hopLimit; i++ {
+		p, ok := pred[cur]
+		if !ok {
+			return Route{}, false
+		}
+		tokens = append(tokens, cur)
+		pools = append(pools, p.pool)
+		cur = p.from
+		if cur == source {
+			tokens = append(tokens, source)
+			break
+		}
+		if visited[cur] {
+			return Route{}, false // walked into an unrelated cycle; bail out
+		}
+		visited[cur] = true
+	}
+	if len(tokens) == 0 || tokens[len(tokens)-1] != source {
+		return Route{}, false
+	}
+
+	// pred was walked backward from source, so reverse both slices.
+	for i, j := 0, len(tokens)-1; i < j; i, j = i+1, j-1 {
+		tokens[i], tokens[j] = tokens[j], tokens[i]
+	}
+	for i, j := 0, len(pools)-1; i < j; i, j = i+1, j-1 {
+		pools[i], pools[j] = pools[j], pools[i]
+	}
+
+	return Route{Tokens: tokens, Pools: pools}, true
+}
@@ -0,0 +1,68 @@
+package arbitrage
+
+import "github.com/ethereum/go-ethereum/common"
+
+// Graph indexes pools by the tokens they connect, so the scanner can pull a
+// bounded local neighborhood around a just-touched token instead of
+// recomputing the full cross-DEX token graph on every swap.
+type Graph struct {
+	byToken map[common.Address][]*Pool
+}
+
+// NewGraph indexes pools by token. A pool that fails to validate isn't
+// expected here; callers configure pools explicitly rather than discovering
+// them on-chain.
+func NewGraph(pools []*Pool) *Graph {
+	g := &Graph{byToken: make(map[common.Address][]*Pool)}
+	for _, p := range pools {
+		g.byToken[p.Token0] = append(g.byToken[p.Token0], p)
+		g.byToken[p.Token1] = append(g.byToken[p.Token1], p)
+	}
+	return g
+}
+
+// PoolsForPair returns every pool (one per DEX that lists the pair)
+// directly connecting a and b.
+func (g *Graph) PoolsForPair(a, b common.Address) []*Pool {
+	var out []*Pool
+	for _, p := range g.byToken[a] {
+		if p.Token0 == b || p.Token1 == b {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// Subgraph returns every pool reachable from start within hopLimit hops,
+// via breadth-first search over shared tokens. This is the "touched
+// subgraph" the scanner re-evaluates instead of the full token graph.
+func (g *Graph) Subgraph(start common.Address, hopLimit int) []*Pool {
+	visitedTokens := map[common.Address]bool{start: true}
+	visitedPools := make(map[common.Address]*Pool)
+	frontier := []common.Address{start}
+
+	for hop := 0; hop < hopLimit && len(frontier) > 0; hop++ {
+		var next []common.Address
+		for _, tok := range frontier {
+			for _, p := range g.byToken[tok] {
+				visitedPools[p.Address] = p
+
+				other := p.Token1
+				if tok == p.Token1 {
+					other = p.Token0
+				}
+				if !visitedTokens[other] {
+					visitedTokens[other] = true
+					next = append(next, other)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	out := make([]*Pool, 0, len(visitedPools))
+	for _, p := range visitedPools {
+		out = append(out, p)
+	}
+	return out
+}
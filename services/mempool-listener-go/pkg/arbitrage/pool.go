@@ -0,0 +1,68 @@
+// Package arbitrage watches decoded DEX swaps for constant-product pools
+// across Trader Joe, Pangolin, and SushiSwap on Avalanche, and scans for
+// profitable arbitrage cycles in the neighborhood of whatever pool each
+// swap just touched.
+package arbitrage
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Pool is one on-chain constant-product liquidity pool (a UniswapV2-style
+// pair contract) the scanner tracks. Reserves are refreshed on demand via
+// RefreshReserves rather than subscribed to, since they only matter for
+// pairs a just-seen pending swap actually touches.
+type Pool struct {
+	Address        common.Address
+	DEX            string // "trader-joe", "pangolin", "sushiswap"
+	Token0, Token1 common.Address
+	FeeBps         uint32 // swap fee in basis points, e.g. 30 for 0.3%
+
+	mu                 sync.RWMutex
+	reserve0, reserve1 *big.Int
+}
+
+// NewPool describes a pool to track. Its reserves are unset until the
+// first RefreshReserves call.
+func NewPool(address, token0, token1 common.Address, dex string, feeBps uint32) *Pool {
+	return &Pool{Address: address, Token0: token0, Token1: token1, DEX: dex, FeeBps: feeBps}
+}
+
+func (p *Pool) setReserves(r0, r1 *big.Int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.reserve0, p.reserve1 = r0, r1
+}
+
+// PriceOut returns how many `out` tokens a marginal unit of `in` buys at
+// the pool's current reserves, net of its swap fee. ok is false if
+// reserves haven't been fetched yet, a reserve is zero, or (in, out) isn't
+// this pool's token pair.
+func (p *Pool) PriceOut(in, out common.Address) (price float64, ok bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.reserve0 == nil || p.reserve1 == nil {
+		return 0, false
+	}
+
+	var rIn, rOut *big.Int
+	switch {
+	case in == p.Token0 && out == p.Token1:
+		rIn, rOut = p.reserve0, p.reserve1
+	case in == p.Token1 && out == p.Token0:
+		rIn, rOut = p.reserve1, p.reserve0
+	default:
+		return 0, false
+	}
+	if rIn.Sign() == 0 || rOut.Sign() == 0 {
+		return 0, false
+	}
+
+	ratio, _ := new(big.Float).Quo(new(big.Float).SetInt(rOut), new(big.Float).SetInt(rIn)).Float64()
+	feeMultiplier := float64(10_000-p.FeeBps) / 10_000
+	return ratio * feeMultiplier, true
+}
@@ -0,0 +1,74 @@
+package arbitrage
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// getReservesSelector is the 4-byte selector for getReserves() on a
+// UniswapV2-style pair contract.
+var getReservesSelector = common.FromHex("0x0902f1ac")
+
+// BatchCaller is the subset of *rpc.Client (or *mempool.SubscriptionManager)
+// RefreshReserves needs to batch its eth_call requests.
+type BatchCaller interface {
+	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
+}
+
+// RefreshReserves batches an eth_call to getReserves() for every pool into
+// a single round trip via BatchCallContext and updates each pool's
+// in-memory reserves from the response.
+func RefreshReserves(ctx context.Context, client BatchCaller, pools []*Pool) error {
+	if len(pools) == 0 {
+		return nil
+	}
+
+	batch := make([]rpc.BatchElem, len(pools))
+	results := make([]hexutil.Bytes, len(pools))
+	for i, p := range pools {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_call",
+			Args: []interface{}{
+				map[string]interface{}{
+					"to":   p.Address,
+					"data": hexutil.Encode(getReservesSelector),
+				},
+				"latest",
+			},
+			Result: &results[i],
+		}
+	}
+
+	if err := client.BatchCallContext(ctx, batch); err != nil {
+		return fmt.Errorf("arbitrage: batch getReserves: %w", err)
+	}
+
+	for i, p := range pools {
+		if batch[i].Error != nil {
+			return fmt.Errorf("arbitrage: getReserves(%s): %w", p.Address, batch[i].Error)
+		}
+		r0, r1, err := decodeReserves(results[i])
+		if err != nil {
+			return fmt.Errorf("arbitrage: decoding reserves for %s: %w", p.Address, err)
+		}
+		p.setReserves(r0, r1)
+	}
+	return nil
+}
+
+// decodeReserves reads reserve0 and reserve1 from a getReserves() return
+// value (reserve0 uint112, reserve1 uint112, blockTimestampLast uint32),
+// each ABI-padded to its own 32-byte word.
+func decodeReserves(data []byte) (*big.Int, *big.Int, error) {
+	if len(data) < 64 {
+		return nil, nil, fmt.Errorf("short getReserves response: %d bytes", len(data))
+	}
+	reserve0 := new(big.Int).SetBytes(data[0:32])
+	reserve1 := new(big.Int).SetBytes(data[32:64])
+	return reserve0, reserve1, nil
+}
@@ -0,0 +1,116 @@
+package arbitrage
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"mev-bot/mempool-listener/pkg/swap"
+)
+
+// gasPerHop is a rough estimate of the gas a single swap hop costs inside
+// an arbitrage bundle. It's only used to size the profit estimate below,
+// not to submit anything on-chain.
+const gasPerHop = 120_000
+
+// Opportunity is a candidate arbitrage route with its estimated profit,
+// net of gas, for trading NotionalIn of the route's start token around the
+// cycle.
+type Opportunity struct {
+	Route      Route
+	NotionalIn *big.Int
+	ProfitWei  *big.Int
+}
+
+// Scanner watches decoded swaps, keeps the pools they touch fresh, and
+// looks for profitable arbitrage cycles in the local neighborhood of every
+// token those swaps route through.
+//
+// Profit is estimated in units of NotionalIn's token, compared directly
+// against an estimated gas cost in wei. That's only meaningful when routes
+// start from the chain's wrapped native token (WAVAX on Avalanche), or a
+// token priced 1:1 against it. OnSwap scans from every token on a swap's
+// path and does not filter routes by their start token.
+type Scanner struct {
+	client     BatchCaller
+	graph      *Graph
+	hopLimit   int
+	notionalIn *big.Int
+}
+
+// NewScanner builds a Scanner over graph, bounding cycle search to hopLimit
+// hops and sizing profit estimates against a notionalIn-sized trade.
+func NewScanner(client BatchCaller, graph *Graph, hopLimit int, notionalIn *big.Int) *Scanner {
+	return &Scanner{client: client, graph: graph, hopLimit: hopLimit, notionalIn: notionalIn}
+}
+
+// OnSwap refreshes the reserves of every pool along ev's path and re-scans
+// the local neighborhood of each token it touches for negative cycles,
+// returning the ones still profitable after an estimated gas cost at ev's
+// gas price. It's a no-op for swaps the scanner has no pools configured
+// for, and for non-swap events (e.g. blob txs, which have no Path).
+func (s *Scanner) OnSwap(ctx context.Context, ev *swap.Event) ([]Opportunity, error) {
+	if len(ev.Path) < 2 {
+		return nil, nil
+	}
+
+	touched := s.touchedPools(ev.Path)
+	if len(touched) == 0 {
+		return nil, nil
+	}
+	if err := RefreshReserves(ctx, s.client, touched); err != nil {
+		return nil, fmt.Errorf("arbitrage: refreshing reserves: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var opps []Opportunity
+	for _, token := range ev.Path {
+		sub := s.graph.Subgraph(token, s.hopLimit)
+		for _, route := range negativeCycles(sub, token, s.hopLimit) {
+			key := route.key()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			if opp, ok := s.estimate(route, ev.GasPrice); ok {
+				opps = append(opps, opp)
+			}
+		}
+	}
+	return opps, nil
+}
+
+// touchedPools returns every configured pool directly connecting
+// consecutive tokens on path, across all DEXes.
+func (s *Scanner) touchedPools(path []common.Address) []*Pool {
+	var pools []*Pool
+	for i := 0; i+1 < len(path); i++ {
+		pools = append(pools, s.graph.PoolsForPair(path[i], path[i+1])...)
+	}
+	return pools
+}
+
+// estimate converts route's log-space profit into an absolute profit in
+// wei for a NotionalIn-sized trade, net of gasPrice * gasPerHop per pool
+// hop. It returns ok=false when the route isn't profitable after gas.
+func (s *Scanner) estimate(route Route, gasPrice *big.Int) (Opportunity, bool) {
+	multiplier := math.Exp(-route.LogProfit) // > 1 for a profitable cycle
+	gross := new(big.Float).Mul(new(big.Float).SetInt(s.notionalIn), big.NewFloat(multiplier-1))
+
+	gasCost := new(big.Int)
+	if gasPrice != nil {
+		gasCost.Mul(gasPrice, big.NewInt(gasPerHop*int64(len(route.Pools))))
+	}
+
+	profit := new(big.Float).Sub(gross, new(big.Float).SetInt(gasCost))
+	if profit.Sign() <= 0 {
+		return Opportunity{}, false
+	}
+
+	profitWei, _ := profit.Int(nil)
+	return Opportunity{Route: route, NotionalIn: s.notionalIn, ProfitWei: profitWei}, true
+}
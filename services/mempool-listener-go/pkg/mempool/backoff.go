@@ -0,0 +1,16 @@
+package mempool
+
+import (
+	"math/rand"
+	"time"
+)
+
+// backoff computes exponential reconnect delays with full jitter, capped at
+// max. attempt is 0-indexed (the first retry after the initial failure).
+func backoff(attempt int, base, max time.Duration) time.Duration {
+	d := base << attempt // exponential growth
+	if d <= 0 || d > max {
+		d = max
+	}
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
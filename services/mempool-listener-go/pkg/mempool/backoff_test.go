@@ -0,0 +1,55 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffCapsAtMax(t *testing.T) {
+	const max = 30 * time.Second
+	for attempt := 0; attempt < 10; attempt++ {
+		d := backoff(attempt, 250*time.Millisecond, max)
+		if d < 0 || d > max {
+			t.Fatalf("attempt %d: backoff returned %s, want within [0, %s]", attempt, d, max)
+		}
+	}
+}
+
+func TestBackoffGrowsWithAttempt(t *testing.T) {
+	const base = time.Millisecond
+	const max = time.Hour
+
+	// Jitter makes any single sample unreliable, so assert on the
+	// theoretical ceiling (base << attempt) growing instead.
+	prevCeiling := time.Duration(0)
+	for attempt := 0; attempt < 8; attempt++ {
+		ceiling := base << attempt
+		if ceiling <= prevCeiling {
+			t.Fatalf("attempt %d: ceiling %s did not grow past previous %s", attempt, ceiling, prevCeiling)
+		}
+		prevCeiling = ceiling
+
+		d := backoff(attempt, base, max)
+		if d > ceiling {
+			t.Fatalf("attempt %d: backoff %s exceeded ceiling %s", attempt, d, ceiling)
+		}
+	}
+}
+
+func TestBackoffOverflowFallsBackToMax(t *testing.T) {
+	// A large enough attempt shifts base past the range a time.Duration
+	// (int64) can represent, which must fall back to max rather than wrap
+	// around to a negative or tiny delay.
+	d := backoff(128, time.Second, time.Minute)
+	if d < 0 || d > time.Minute {
+		t.Fatalf("backoff(128, ...) = %s, want within [0, 1m] after overflow", d)
+	}
+}
+
+func TestBackoffZeroAttemptWithinBase(t *testing.T) {
+	const base = 250 * time.Millisecond
+	d := backoff(0, base, time.Minute)
+	if d < 0 || d > base {
+		t.Fatalf("backoff(0, ...) = %s, want within [0, %s]", d, base)
+	}
+}
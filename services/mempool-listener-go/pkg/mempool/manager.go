@@ -0,0 +1,299 @@
+// Package mempool provides a reconnecting wrapper around go-ethereum's RPC
+// subscription client so that a dropped WebSocket connection doesn't need
+// to be handled by every caller.
+package mempool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"mev-bot/mempool-listener/internal/transport"
+)
+
+const (
+	defaultBackoffBase = 250 * time.Millisecond
+	defaultBackoffMax  = 30 * time.Second
+	pendingTxRingSize  = 4096
+)
+
+// Metrics is a point-in-time snapshot of a SubscriptionManager's lifecycle
+// counters, suitable for exposing on a /metrics endpoint.
+type Metrics struct {
+	ReconnectCount int
+	LastError      error
+	Uptime         time.Duration
+}
+
+// SubscriptionManager owns a single upstream WebSocket connection and keeps
+// it alive: when the connection drops it re-dials with exponential backoff
+// and jitter and re-issues every subscription previously registered through
+// Subscribe, so callers never see the underlying reconnect.
+type SubscriptionManager struct {
+	url string
+
+	mu          sync.Mutex
+	client      transport.Transport
+	subs        []*managedSub
+	reconnects  int
+	lastErr     error
+	connectedAt time.Time
+	closed      bool
+}
+
+// managedSub is one subscription the manager keeps re-establishing across
+// reconnects, forwarding decoded values onto the caller's channel.
+type managedSub struct {
+	topic   string
+	args    []interface{}
+	userCh  reflect.Value
+	elemTyp reflect.Type
+	dedup   *hashRing
+
+	mu  sync.Mutex
+	sub transport.Subscription
+}
+
+// NewSubscriptionManager dials url and returns a manager ready for
+// Subscribe calls. The initial dial is not retried; later drops are
+// detected through the subscriptions registered with Subscribe, each of
+// which triggers a background reconnect when it fails.
+func NewSubscriptionManager(ctx context.Context, url string) (*SubscriptionManager, error) {
+	client, err := transport.Dial(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("mempool: dial %s: %w", url, err)
+	}
+	return &SubscriptionManager{
+		url:         url,
+		client:      client,
+		connectedAt: time.Now(),
+	}, nil
+}
+
+// Subscribe registers a subscription for topic (e.g.
+// "newPendingTransactions", "newHeads", "logs") and forwards decoded values
+// onto ch for as long as the manager is running, transparently
+// re-subscribing across reconnects. ch must be a directional-or-bidirectional
+// channel, matching what rpc.Client.EthSubscribe expects for the topic.
+//
+// For "newPendingTransactions" specifically, ch's element type must be
+// common.Hash; values replayed by a node after reconnect are deduplicated
+// against a bounded ring buffer of recently seen hashes.
+func (m *SubscriptionManager) Subscribe(ctx context.Context, topic string, ch interface{}, args ...interface{}) error {
+	chVal := reflect.ValueOf(ch)
+	if chVal.Kind() != reflect.Chan {
+		return fmt.Errorf("mempool: Subscribe: ch must be a channel, got %T", ch)
+	}
+
+	ms := &managedSub{
+		topic:   topic,
+		args:    args,
+		userCh:  chVal,
+		elemTyp: chVal.Type().Elem(),
+	}
+	if topic == "newPendingTransactions" && ms.elemTyp == reflect.TypeOf(common.Hash{}) {
+		ms.dedup = newHashRing(pendingTxRingSize)
+	}
+
+	// m.mu is held across the append-and-start sequence, not just the
+	// append: releasing it in between would let a concurrent reconnect
+	// snapshot m.subs (already including ms) and call start for it on the
+	// new client while this call is still calling start on the old one,
+	// racing to set ms.sub and double-starting ms's relay/watch goroutines.
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	client := m.client
+	m.subs = append(m.subs, ms)
+
+	return m.start(ctx, client, ms)
+}
+
+// start issues the underlying EthSubscribe call for ms against client and
+// launches the goroutine that relays and dedups values onto the user
+// channel, plus the goroutine that watches for subscription errors and
+// triggers a reconnect.
+func (m *SubscriptionManager) start(ctx context.Context, client transport.Transport, ms *managedSub) error {
+	internalCh := reflect.MakeChan(reflect.ChanOf(reflect.BothDir, ms.elemTyp), 256)
+
+	subArgs := append([]interface{}{ms.topic}, ms.args...)
+	sub, err := client.EthSubscribe(ctx, internalCh.Interface(), subArgs...)
+	if err != nil {
+		return fmt.Errorf("mempool: subscribe %s: %w", ms.topic, err)
+	}
+
+	ms.mu.Lock()
+	ms.sub = sub
+	ms.mu.Unlock()
+
+	go ms.relay(internalCh)
+	go m.watch(ctx, client, sub, internalCh)
+
+	return nil
+}
+
+// relay forwards everything received on internalCh to the user channel,
+// applying dedup when configured. It exits when internalCh is closed,
+// which happens when the manager tears down a dead subscription.
+func (ms *managedSub) relay(internalCh reflect.Value) {
+	for {
+		v, ok := internalCh.Recv()
+		if !ok {
+			return
+		}
+		if ms.dedup != nil {
+			h := v.Interface().(common.Hash)
+			if !ms.dedup.addIfNew(h) {
+				continue
+			}
+		}
+		ms.userCh.Send(v)
+	}
+}
+
+// watch blocks until sub reports an error (including the one sent on
+// Unsubscribe/close), then asks the manager to reconnect. It returns
+// without acting if ctx is canceled first. Either way it closes internalCh
+// once sub's forwarding loop has stopped, so the paired relay goroutine
+// doesn't leak blocked on a subscription nothing will ever write to again.
+func (m *SubscriptionManager) watch(ctx context.Context, client transport.Transport, sub transport.Subscription, internalCh reflect.Value) {
+	select {
+	case err := <-sub.Err():
+		internalCh.Close()
+		if err == nil {
+			return // clean Unsubscribe, not a drop
+		}
+		m.reconnect(ctx, client, err)
+	case <-ctx.Done():
+		// Unsubscribe blocks until the forwarding loop has fully stopped
+		// sending into internalCh, so closing it right after is safe.
+		sub.Unsubscribe()
+		internalCh.Close()
+	}
+}
+
+// reconnect re-dials the upstream endpoint with exponential backoff and
+// jitter, then re-issues every managed subscription. It's safe to call
+// concurrently; only the first caller for a given dead client performs the
+// work, others observe the already-refreshed client and return.
+func (m *SubscriptionManager) reconnect(ctx context.Context, dead transport.Transport, cause error) {
+	m.mu.Lock()
+	if m.closed || m.client != dead {
+		m.mu.Unlock()
+		return
+	}
+	m.lastErr = cause
+	m.mu.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		m.mu.Lock()
+		current, closed := m.client, m.closed
+		m.mu.Unlock()
+		if closed || current != dead {
+			// Closed, or someone else already reconnected.
+			return
+		}
+
+		delay := backoff(attempt, defaultBackoffBase, defaultBackoffMax)
+		log.Printf("mempool: reconnecting to %s in %s (attempt %d): %v", m.url, delay, attempt+1, cause)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return
+		}
+
+		client, err := transport.Dial(ctx, m.url)
+		if err != nil {
+			cause = err
+			continue
+		}
+
+		m.mu.Lock()
+		if m.closed || m.client != dead {
+			m.mu.Unlock()
+			client.Close()
+			return
+		}
+		m.client = client
+		m.reconnects++
+		m.connectedAt = time.Now()
+		subs := append([]*managedSub(nil), m.subs...)
+		m.mu.Unlock()
+
+		var resubErr error
+		for _, ms := range subs {
+			if err := m.start(ctx, client, ms); err != nil {
+				resubErr = errors.Join(resubErr, err)
+			}
+		}
+		if resubErr != nil {
+			// Retry against a fresh connection; the one just dialed becomes
+			// the dead client the next attempt replaces.
+			cause = resubErr
+			dead.Close()
+			dead = client
+			continue
+		}
+
+		dead.Close()
+		return
+	}
+}
+
+// CallContext performs a single RPC call against the manager's current
+// connection, so non-subscription requests (e.g. eth_getRawTransactionByHash)
+// go through the same client the manager keeps alive rather than one that
+// may have already been replaced after a reconnect.
+func (m *SubscriptionManager) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	m.mu.Lock()
+	client := m.client
+	m.mu.Unlock()
+	return client.CallContext(ctx, result, method, args...)
+}
+
+// BatchCallContext performs a batch of RPC calls against the manager's
+// current connection, same rationale as CallContext.
+func (m *SubscriptionManager) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	m.mu.Lock()
+	client := m.client
+	m.mu.Unlock()
+	return client.BatchCallContext(ctx, b)
+}
+
+// Metrics returns a snapshot of the manager's reconnect count, last
+// observed error, and time since the current connection was established.
+func (m *SubscriptionManager) Metrics() Metrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return Metrics{
+		ReconnectCount: m.reconnects,
+		LastError:      m.lastErr,
+		Uptime:         time.Since(m.connectedAt),
+	}
+}
+
+// Close unsubscribes every managed subscription, tears down the current
+// connection, and stops any in-flight reconnect attempts.
+func (m *SubscriptionManager) Close() {
+	m.mu.Lock()
+	m.closed = true
+	client := m.client
+	subs := append([]*managedSub(nil), m.subs...)
+	m.mu.Unlock()
+
+	for _, ms := range subs {
+		ms.mu.Lock()
+		sub := ms.sub
+		ms.mu.Unlock()
+		if sub != nil {
+			sub.Unsubscribe()
+		}
+	}
+	client.Close()
+}
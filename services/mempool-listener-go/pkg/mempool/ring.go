@@ -0,0 +1,49 @@
+package mempool
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// hashRing is a bounded, fixed-capacity set of recently seen tx hashes. It
+// lets Subscribe's "newPendingTransactions" path drop duplicates a
+// just-reconnected node replays from its local mempool, without growing
+// unbounded over a long-lived subscription.
+type hashRing struct {
+	mu       sync.Mutex
+	capacity int
+	buf      []common.Hash
+	pos      int
+	seen     map[common.Hash]struct{}
+}
+
+func newHashRing(capacity int) *hashRing {
+	return &hashRing{
+		capacity: capacity,
+		buf:      make([]common.Hash, 0, capacity),
+		seen:     make(map[common.Hash]struct{}, capacity),
+	}
+}
+
+// addIfNew records h and returns true if it hasn't been seen before (within
+// the ring's retention window), false if it's a duplicate.
+func (r *hashRing) addIfNew(h common.Hash) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.seen[h]; ok {
+		return false
+	}
+
+	if len(r.buf) < r.capacity {
+		r.buf = append(r.buf, h)
+	} else {
+		evict := r.buf[r.pos]
+		delete(r.seen, evict)
+		r.buf[r.pos] = h
+		r.pos = (r.pos + 1) % r.capacity
+	}
+	r.seen[h] = struct{}{}
+	return true
+}
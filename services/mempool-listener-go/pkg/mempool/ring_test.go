@@ -0,0 +1,60 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func hashOf(b byte) common.Hash {
+	var h common.Hash
+	h[len(h)-1] = b
+	return h
+}
+
+func TestHashRingAddIfNewDetectsDuplicate(t *testing.T) {
+	r := newHashRing(4)
+
+	if !r.addIfNew(hashOf(1)) {
+		t.Fatal("first insert of a hash should report new")
+	}
+	if r.addIfNew(hashOf(1)) {
+		t.Fatal("second insert of the same hash should report duplicate")
+	}
+}
+
+func TestHashRingEvictsOldestOnceFull(t *testing.T) {
+	r := newHashRing(2)
+
+	r.addIfNew(hashOf(1))
+	r.addIfNew(hashOf(2))
+
+	// hashOf(2) is still within the retention window: check it's seen as
+	// a duplicate before consuming the check below, which (being a new
+	// hash) evicts the oldest entry and mutates the ring.
+	if r.addIfNew(hashOf(2)) {
+		t.Fatal("hashOf(2) is still within the retention window and should be a duplicate")
+	}
+
+	r.addIfNew(hashOf(3)) // evicts hashOf(1); ring now holds {2, 3}
+
+	if !r.addIfNew(hashOf(1)) {
+		t.Fatal("evicted hash should be treated as new again")
+	}
+}
+
+func TestHashRingNeverExceedsCapacity(t *testing.T) {
+	const capacity = 8
+	r := newHashRing(capacity)
+
+	for i := 0; i < capacity*4; i++ {
+		r.addIfNew(hashOf(byte(i)))
+	}
+
+	if len(r.seen) != capacity {
+		t.Fatalf("len(seen) = %d, want %d", len(r.seen), capacity)
+	}
+	if len(r.buf) != capacity {
+		t.Fatalf("len(buf) = %d, want %d", len(r.buf), capacity)
+	}
+}
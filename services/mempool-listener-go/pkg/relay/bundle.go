@@ -0,0 +1,85 @@
+package relay
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Bundle is an ordered set of transactions to be included atomically in a
+// single target block.
+type Bundle struct {
+	Txs         []*types.Transaction
+	BlockNumber uint64
+
+	// MinTimestamp and MaxTimestamp optionally bound the block timestamp
+	// the bundle is valid for; zero means omit the constraint.
+	MinTimestamp uint64
+	MaxTimestamp uint64
+}
+
+func (b Bundle) encodedTxs() ([]string, error) {
+	out := make([]string, len(b.Txs))
+	for i, tx := range b.Txs {
+		raw, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("relay: encoding tx %d: %w", i, err)
+		}
+		out[i] = hexutil.Encode(raw)
+	}
+	return out, nil
+}
+
+// params builds the common eth_sendBundle/eth_callBundle parameter object.
+func (b Bundle) params() (map[string]interface{}, error) {
+	txs, err := b.encodedTxs()
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]interface{}{
+		"txs":         txs,
+		"blockNumber": hexutil.EncodeUint64(b.BlockNumber),
+	}
+	if b.MinTimestamp != 0 {
+		params["minTimestamp"] = b.MinTimestamp
+	}
+	if b.MaxTimestamp != 0 {
+		params["maxTimestamp"] = b.MaxTimestamp
+	}
+	return params, nil
+}
+
+// SendBundleResult is the eth_sendBundle/mev_sendBundle response.
+type SendBundleResult struct {
+	BundleHash string `json:"bundleHash"`
+}
+
+// CallBundleResult is the eth_callBundle simulation response: what the
+// bundle would have done had it landed, used to catch a revert before
+// actually submitting.
+type CallBundleResult struct {
+	BundleHash   string               `json:"bundleHash"`
+	CoinbaseDiff string               `json:"coinbaseDiff"`
+	Results      []CallBundleTxResult `json:"results"`
+}
+
+// CallBundleTxResult is one transaction's outcome within a simulated
+// bundle.
+type CallBundleTxResult struct {
+	TxHash  string `json:"txHash"`
+	GasUsed uint64 `json:"gasUsed"`
+	Error   string `json:"error,omitempty"`
+	Revert  string `json:"revert,omitempty"`
+}
+
+// Reverted reports whether any transaction in the simulated bundle
+// reverted or otherwise errored.
+func (r CallBundleResult) Reverted() bool {
+	for _, tx := range r.Results {
+		if tx.Error != "" || tx.Revert != "" {
+			return true
+		}
+	}
+	return false
+}
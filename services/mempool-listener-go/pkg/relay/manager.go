@@ -0,0 +1,96 @@
+package relay
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// RelayStats tracks how many bundle submissions to a relay have succeeded
+// or failed, for basic per-relay reliability tracking.
+type RelayStats struct {
+	Successes int
+	Failures  int
+}
+
+// Manager submits bundles to multiple relays concurrently and tracks each
+// relay's success rate.
+type Manager struct {
+	relays []*Relay
+
+	mu    sync.Mutex
+	stats map[string]*RelayStats
+}
+
+// NewManager builds a Manager over relays.
+func NewManager(relays ...*Relay) *Manager {
+	stats := make(map[string]*RelayStats, len(relays))
+	for _, r := range relays {
+		stats[r.Name] = &RelayStats{}
+	}
+	return &Manager{relays: relays, stats: stats}
+}
+
+// SubmitResult is one relay's outcome for a bundle submission.
+type SubmitResult struct {
+	Relay  string
+	Result *SendBundleResult
+	Err    error
+}
+
+// SendBundle submits b to every configured relay concurrently, returning
+// once all of them have responded.
+func (m *Manager) SendBundle(ctx context.Context, b Bundle) []SubmitResult {
+	results := make([]SubmitResult, len(m.relays))
+
+	var wg sync.WaitGroup
+	for i, r := range m.relays {
+		wg.Add(1)
+		go func(i int, r *Relay) {
+			defer wg.Done()
+			result, err := r.SendBundle(ctx, b)
+			results[i] = SubmitResult{Relay: r.Name, Result: result, Err: err}
+			m.record(r.Name, err == nil)
+		}(i, r)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// CallBundle simulates b against the first configured relay. Flashbots-
+// compatible relays simulate against the same pending block state
+// regardless of which one is asked, so a single simulation is enough to
+// decide whether a bundle is safe to send everywhere.
+func (m *Manager) CallBundle(ctx context.Context, b Bundle) (*CallBundleResult, error) {
+	if len(m.relays) == 0 {
+		return nil, fmt.Errorf("relay: no relays configured")
+	}
+	return m.relays[0].CallBundle(ctx, b)
+}
+
+func (m *Manager) record(relay string, success bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.stats[relay]
+	if s == nil {
+		return
+	}
+	if success {
+		s.Successes++
+	} else {
+		s.Failures++
+	}
+}
+
+// Stats returns a snapshot of each relay's submission success/failure
+// counts.
+func (m *Manager) Stats() map[string]RelayStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make(map[string]RelayStats, len(m.stats))
+	for name, s := range m.stats {
+		out[name] = *s
+	}
+	return out
+}
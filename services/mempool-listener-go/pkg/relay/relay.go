@@ -0,0 +1,163 @@
+// Package relay submits signed transactions either publicly via
+// eth_sendRawTransaction or privately as a Flashbots-compatible bundle, and
+// decides between the two based on a candidate's estimated profit and
+// revert risk.
+package relay
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const defaultRequestTimeout = 5 * time.Second
+
+// defaultSendMethod is the JSON-RPC method most Flashbots-compatible
+// relays expose for bundle submission.
+const defaultSendMethod = "eth_sendBundle"
+
+// Relay describes one private-order-flow endpoint this bot can submit
+// bundles to (e.g. a Flashbots-compatible relay on another chain, or a
+// regional MEV relay). Every request is authenticated with the
+// X-Flashbots-Signature header: signingKey signs over the request body, not
+// over the bundled transactions themselves.
+type Relay struct {
+	Name       string
+	URL        string
+	signingKey *ecdsa.PrivateKey
+
+	// SendMethod is the JSON-RPC method used for bundle submission. It
+	// defaults to "eth_sendBundle"; some relays (e.g. MEV-Share-compatible
+	// ones) expect "mev_sendBundle" instead.
+	SendMethod string
+
+	httpClient *http.Client
+}
+
+// NewRelay describes a relay at url, authenticating requests with
+// signingKey per the Flashbots signed-bundle convention.
+func NewRelay(name, url string, signingKey *ecdsa.PrivateKey) *Relay {
+	return &Relay{
+		Name:       name,
+		URL:        url,
+		signingKey: signingKey,
+		httpClient: &http.Client{Timeout: defaultRequestTimeout},
+	}
+}
+
+// signatureHeader computes the X-Flashbots-Signature header value for body:
+// "<signer address>:<hex signature over the EIP-191 personal-sign hash of
+// keccak256(body)>". Relays verify against the personal-sign hash, not the
+// raw digest, so signing has to go through accounts.TextHash to recover to
+// the expected signer address.
+func (r *Relay) signatureHeader(body []byte) (string, error) {
+	hash := crypto.Keccak256Hash(body)
+	sig, err := crypto.Sign(accounts.TextHash(hash.Bytes()), r.signingKey)
+	if err != nil {
+		return "", fmt.Errorf("relay: signing request body: %w", err)
+	}
+	addr := crypto.PubkeyToAddress(r.signingKey.PublicKey)
+	return fmt.Sprintf("%s:%s", addr.Hex(), hexutil.Encode(sig)), nil
+}
+
+type jsonRPCRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type jsonRPCResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *jsonRPCError   `json:"error"`
+}
+
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// call performs a single signed JSON-RPC request against r, decoding the
+// result into out (which may be nil when the caller doesn't need it).
+func (r *Relay) call(ctx context.Context, method string, params interface{}, out interface{}) error {
+	body, err := json.Marshal(jsonRPCRequest{JSONRPC: "2.0", ID: 1, Method: method, Params: []interface{}{params}})
+	if err != nil {
+		return fmt.Errorf("relay: marshaling request: %w", err)
+	}
+
+	sig, err := r.signatureHeader(body)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, r.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("relay: building request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("X-Flashbots-Signature", sig)
+
+	resp, err := r.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("relay: %s: %w", r.Name, err)
+	}
+	defer resp.Body.Close()
+
+	var rpcResp jsonRPCResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return fmt.Errorf("relay: %s: decoding response: %w", r.Name, err)
+	}
+	if rpcResp.Error != nil {
+		return fmt.Errorf("relay: %s: %s (code %d)", r.Name, rpcResp.Error.Message, rpcResp.Error.Code)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(rpcResp.Result, out); err != nil {
+		return fmt.Errorf("relay: %s: unmarshaling result: %w", r.Name, err)
+	}
+	return nil
+}
+
+// SendBundle submits b to r using r.SendMethod (eth_sendBundle by default).
+func (r *Relay) SendBundle(ctx context.Context, b Bundle) (*SendBundleResult, error) {
+	params, err := b.params()
+	if err != nil {
+		return nil, err
+	}
+
+	method := r.SendMethod
+	if method == "" {
+		method = defaultSendMethod
+	}
+
+	var result SendBundleResult
+	if err := r.call(ctx, method, params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CallBundle simulates b against r's view of the pending block via
+// eth_callBundle, without submitting it.
+func (r *Relay) CallBundle(ctx context.Context, b Bundle) (*CallBundleResult, error) {
+	params, err := b.params()
+	if err != nil {
+		return nil, err
+	}
+	params["stateBlockNumber"] = "latest"
+
+	var result CallBundleResult
+	if err := r.call(ctx, "eth_callBundle", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
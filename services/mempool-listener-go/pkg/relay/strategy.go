@@ -0,0 +1,90 @@
+package relay
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Candidate is a signed transaction ready to submit, along with the
+// estimates a Strategy uses to decide how to route it.
+type Candidate struct {
+	Tx             *types.Transaction
+	ExpectedProfit *big.Int // wei, net of gas
+	RevertRisk     float64  // estimated probability of reverting, 0..1
+	BlockNumber    uint64   // target block for a private bundle
+}
+
+// Strategy decides whether a candidate should be routed privately (as a
+// bundle through relays) or broadcast publicly via eth_sendRawTransaction.
+type Strategy interface {
+	RoutePrivately(c Candidate) bool
+}
+
+// ProfitRevertStrategy routes privately whenever a candidate is either
+// profitable enough to be worth protecting from frontrunning, or risky
+// enough that a public revert would waste gas and reveal the strategy.
+type ProfitRevertStrategy struct {
+	MinPrivateProfit    *big.Int
+	MaxPublicRevertRisk float64
+}
+
+// RoutePrivately implements Strategy.
+func (s ProfitRevertStrategy) RoutePrivately(c Candidate) bool {
+	if c.RevertRisk > s.MaxPublicRevertRisk {
+		return true
+	}
+	return s.MinPrivateProfit != nil && c.ExpectedProfit != nil && c.ExpectedProfit.Cmp(s.MinPrivateProfit) >= 0
+}
+
+// PublicSender broadcasts a raw signed transaction, e.g.
+// *mempool.SubscriptionManager or *rpc.Client, both of which expose
+// eth_sendRawTransaction via CallContext.
+type PublicSender interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// Executor routes a Candidate to either a public node or a set of private
+// relays, based on Strategy's decision.
+type Executor struct {
+	Strategy Strategy
+	Public   PublicSender
+	Relays   *Manager
+}
+
+// Submit routes c according to e.Strategy. A privately-routed candidate is
+// first simulated via eth_callBundle; if the simulation reverts, Submit
+// returns an error instead of sending the bundle.
+func (e *Executor) Submit(ctx context.Context, c Candidate) ([]SubmitResult, error) {
+	if !e.Strategy.RoutePrivately(c) {
+		return e.submitPublic(ctx, c)
+	}
+
+	bundle := Bundle{Txs: []*types.Transaction{c.Tx}, BlockNumber: c.BlockNumber}
+
+	sim, err := e.Relays.CallBundle(ctx, bundle)
+	if err != nil {
+		return nil, fmt.Errorf("relay: simulating bundle: %w", err)
+	}
+	if sim.Reverted() {
+		return nil, fmt.Errorf("relay: bundle reverts in simulation, not submitting")
+	}
+
+	return e.Relays.SendBundle(ctx, bundle), nil
+}
+
+func (e *Executor) submitPublic(ctx context.Context, c Candidate) ([]SubmitResult, error) {
+	raw, err := c.Tx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("relay: encoding tx: %w", err)
+	}
+
+	var txHash string
+	if err := e.Public.CallContext(ctx, &txHash, "eth_sendRawTransaction", hexutil.Encode(raw)); err != nil {
+		return nil, fmt.Errorf("relay: public broadcast: %w", err)
+	}
+	return []SubmitResult{{Relay: "public", Result: &SendBundleResult{BundleHash: txHash}}}, nil
+}
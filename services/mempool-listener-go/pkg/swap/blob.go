@@ -0,0 +1,58 @@
+package swap
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+// DecodeBlobTx builds an Event for an EIP-4844 blob-carrying transaction.
+// Blob txs have no ABI calldata worth decoding as a swap, so rather than
+// silently dropping them (as a decoder Registry built only for swap
+// selectors would), the caller routes type-3 txs here directly. When a blob
+// sidecar is attached, each blob's commitment is recomputed and checked
+// against the versioned hashes the tx actually commits to.
+func DecodeBlobTx(tx *types.Transaction, sender common.Address) (*Event, error) {
+	if tx.Type() != types.BlobTxType {
+		return nil, fmt.Errorf("swap: DecodeBlobTx called on non-blob tx (type %d)", tx.Type())
+	}
+
+	ev := &Event{
+		TxHash:              tx.Hash(),
+		Protocol:            "blob",
+		Sender:              sender,
+		GasPrice:            tx.GasTipCap(),
+		BlobVersionedHashes: tx.BlobHashes(),
+	}
+
+	sidecar := tx.BlobTxSidecar()
+	if sidecar == nil {
+		// Sidecars aren't gossiped with the tx itself once it leaves the
+		// originating node's pool, so most pending blob txs we see won't
+		// carry one. The versioned hashes above are still enough to track
+		// the tx through the mempool.
+		return ev, nil
+	}
+
+	for i := range sidecar.Blobs {
+		blob := &sidecar.Blobs[i]
+		commitment, err := kzg4844.BlobToCommitment(blob)
+		if err != nil {
+			return nil, fmt.Errorf("swap: computing commitment for blob %d: %w", i, err)
+		}
+		if commitment != sidecar.Commitments[i] {
+			return nil, fmt.Errorf("swap: blob %d commitment mismatch", i)
+		}
+		if i >= len(ev.BlobVersionedHashes) || kzg4844.CalcBlobHashV1(sha256.New(), &commitment) != ev.BlobVersionedHashes[i] {
+			return nil, fmt.Errorf("swap: blob %d does not match the tx's versioned hash", i)
+		}
+		if _, err := kzg4844.ComputeBlobProof(blob, commitment); err != nil {
+			return nil, fmt.Errorf("swap: computing proof for blob %d: %w", i, err)
+		}
+	}
+
+	return ev, nil
+}
@@ -0,0 +1,80 @@
+package swap
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Decoder recognizes and decodes the calldata of one DEX router. Decode
+// returns ok=false (with a nil error) when the transaction's selector isn't
+// one this decoder handles, so a Registry can try the next candidate.
+type Decoder interface {
+	// Name identifies the protocol this decoder understands, used as
+	// Event.Protocol.
+	Name() string
+	Decode(tx *types.Transaction, sender common.Address) (ev *Event, ok bool, err error)
+}
+
+// Registry dispatches a transaction to the first Decoder whose selector
+// matches. Decoders are tried in registration order.
+type Registry struct {
+	decoders []Decoder
+}
+
+// NewRegistry builds a Registry with the given decoders, tried in order.
+func NewRegistry(decoders ...Decoder) *Registry {
+	return &Registry{decoders: decoders}
+}
+
+// DefaultRegistry returns a Registry wired up with the router decoders this
+// bot ships out of the box (Uniswap V2/V3, 1inch, Curve).
+func DefaultRegistry() *Registry {
+	return NewRegistry(
+		&UniswapV2Decoder{},
+		&UniswapV3Decoder{},
+		&OneInchDecoder{},
+		&CurveDecoder{},
+	)
+}
+
+// Decode tries each registered decoder in turn and returns the first match.
+// It returns ok=false if no decoder recognizes the transaction's selector
+// (e.g. it isn't a swap at all).
+func (r *Registry) Decode(tx *types.Transaction, sender common.Address) (*Event, bool, error) {
+	for _, d := range r.decoders {
+		ev, ok, err := d.Decode(tx, sender)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", d.Name(), err)
+		}
+		if ok {
+			ev.Protocol = d.Name()
+			ev.TxHash = tx.Hash()
+			ev.Sender = sender
+			ev.GasPrice = gasPriceOf(tx)
+			return ev, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// gasPriceOf returns the effective gas price field the bot cares about for
+// ranking: GasTipCap for dynamic-fee txs (type 2+), GasPrice for legacy ones.
+func gasPriceOf(tx *types.Transaction) *big.Int {
+	if tx.Type() == types.LegacyTxType || tx.Type() == types.AccessListTxType {
+		return tx.GasPrice()
+	}
+	return tx.GasTipCap()
+}
+
+// selector returns the 4-byte function selector of tx's calldata, or nil if
+// the calldata is too short to contain one.
+func selector(tx *types.Transaction) []byte {
+	data := tx.Data()
+	if len(data) < 4 {
+		return nil
+	}
+	return data[:4]
+}
@@ -0,0 +1,36 @@
+package swap
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// CurveDecoder recognizes the stableswap pool `exchange` entrypoint. Curve
+// pools address their coins by index rather than by address, so a decoded
+// event here carries AmountIn but leaves TokenIn/TokenOut/Path unset -
+// resolving them requires a pool-specific `coins(int128)` lookup this
+// decoder doesn't do. Since arbitrage.Scanner.OnSwap keys off Path, Curve
+// swaps are decoded but never reach arbitrage scanning today.
+type CurveDecoder struct{}
+
+func (CurveDecoder) Name() string { return "curve" }
+
+// exchange(int128 i, int128 j, uint256 dx, uint256 min_dy)
+var selCurveExchange = selectorHex("3df02124")
+
+func (CurveDecoder) Decode(tx *types.Transaction, _ common.Address) (*Event, bool, error) {
+	sel := selector(tx)
+	if sel == nil || !bytes.Equal(sel, selCurveExchange) {
+		return nil, false, nil
+	}
+
+	word, err := wordAt(tx.Data()[4:], 0, 4)
+	if err != nil {
+		return nil, false, err
+	}
+	amountIn := new(big.Int).SetBytes(word(2))
+	return &Event{AmountIn: amountIn}, true, nil
+}
@@ -0,0 +1,57 @@
+package swap
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// OneInchDecoder recognizes the AggregationRouter V5 entrypoints. `swap`
+// carries an explicit SwapDescription with both token addresses; `unoswap`
+// only carries pool addresses, so its decoded event has no TokenOut.
+type OneInchDecoder struct{}
+
+func (OneInchDecoder) Name() string { return "1inch" }
+
+var (
+	// swap(address executor, (address srcToken, address dstToken, address srcReceiver,
+	//   address dstReceiver, uint256 amount, uint256 minReturnAmount, uint256 flags) desc,
+	//   bytes permit, bytes data)
+	selOneInchSwap = selectorHex("12aa3caf")
+	// unoswap(address srcToken, uint256 amount, uint256 minReturn, bytes32[] pools)
+	selOneInchUnoswap = selectorHex("0502b1c5")
+)
+
+func (OneInchDecoder) Decode(tx *types.Transaction, _ common.Address) (*Event, bool, error) {
+	sel := selector(tx)
+	if sel == nil {
+		return nil, false, nil
+	}
+	data := tx.Data()[4:]
+
+	switch {
+	case bytes.Equal(sel, selOneInchSwap):
+		// executor, then the static SwapDescription tuple inlined in place.
+		word, err := wordAt(data, 0, 8)
+		if err != nil {
+			return nil, false, err
+		}
+		tokenIn := common.BytesToAddress(word(1))
+		tokenOut := common.BytesToAddress(word(2))
+		amountIn := new(big.Int).SetBytes(word(5))
+		return &Event{TokenIn: tokenIn, TokenOut: tokenOut, AmountIn: amountIn, Path: []common.Address{tokenIn, tokenOut}}, true, nil
+
+	case bytes.Equal(sel, selOneInchUnoswap):
+		word, err := wordAt(data, 0, 3)
+		if err != nil {
+			return nil, false, err
+		}
+		tokenIn := common.BytesToAddress(word(0))
+		amountIn := new(big.Int).SetBytes(word(1))
+		return &Event{TokenIn: tokenIn, AmountIn: amountIn, Path: []common.Address{tokenIn}}, true, nil
+	}
+
+	return nil, false, nil
+}
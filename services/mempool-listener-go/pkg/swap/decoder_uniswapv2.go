@@ -0,0 +1,107 @@
+package swap
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// UniswapV2Decoder recognizes the handful of swap entrypoints exposed by
+// UniswapV2Router02 and its many forks (Trader Joe, Pangolin, SushiSwap all
+// ship the same router ABI).
+type UniswapV2Decoder struct{}
+
+func (UniswapV2Decoder) Name() string { return "uniswap-v2" }
+
+var (
+	// swapExactTokensForTokens(uint256 amountIn, uint256 amountOutMin, address[] path, address to, uint256 deadline)
+	selV2SwapExactTokensForTokens = selectorHex("38ed1739")
+	// swapTokensForExactTokens(uint256 amountOut, uint256 amountInMax, address[] path, address to, uint256 deadline)
+	selV2SwapTokensForExactTokens = selectorHex("8803dbee")
+	// swapExactETHForTokens(uint256 amountOutMin, address[] path, address to, uint256 deadline) [payable]
+	selV2SwapExactETHForTokens = selectorHex("7ff36ab5")
+	// swapExactTokensForETH(uint256 amountIn, uint256 amountOutMin, address[] path, address to, uint256 deadline)
+	selV2SwapExactTokensForETH = selectorHex("18cbafe5")
+
+	v2ArgsWithAmountIn = mustArguments("uint256", "uint256", "address[]", "address", "uint256")
+	v2ArgsNoAmountIn   = mustArguments("uint256", "address[]", "address", "uint256")
+)
+
+func (UniswapV2Decoder) Decode(tx *types.Transaction, _ common.Address) (*Event, bool, error) {
+	sel := selector(tx)
+	if sel == nil {
+		return nil, false, nil
+	}
+
+	switch {
+	case bytes.Equal(sel, selV2SwapExactTokensForTokens), bytes.Equal(sel, selV2SwapTokensForExactTokens):
+		vals, err := v2ArgsWithAmountIn.Unpack(tx.Data()[4:])
+		if err != nil {
+			return nil, false, err
+		}
+		amountIn := vals[0].(*big.Int)
+		path := vals[2].([]common.Address)
+		var amountOut *big.Int
+		if bytes.Equal(sel, selV2SwapTokensForExactTokens) {
+			// amountOut, amountInMax, ...: amountIn is a max, not exact; still
+			// the best available estimate until the swap actually executes.
+			// amountOut, by contrast, is exact here, so capture it.
+			amountOut = vals[0].(*big.Int)
+			amountIn = vals[1].(*big.Int)
+		}
+		return &Event{AmountIn: amountIn, AmountOut: amountOut, Path: path, TokenIn: firstOf(path), TokenOut: lastOf(path)}, true, nil
+
+	case bytes.Equal(sel, selV2SwapExactETHForTokens):
+		vals, err := v2ArgsNoAmountIn.Unpack(tx.Data()[4:])
+		if err != nil {
+			return nil, false, err
+		}
+		path := vals[1].([]common.Address)
+		return &Event{AmountIn: tx.Value(), Path: path, TokenIn: firstOf(path), TokenOut: lastOf(path)}, true, nil
+
+	case bytes.Equal(sel, selV2SwapExactTokensForETH):
+		vals, err := v2ArgsWithAmountIn.Unpack(tx.Data()[4:])
+		if err != nil {
+			return nil, false, err
+		}
+		amountIn := vals[0].(*big.Int)
+		path := vals[2].([]common.Address)
+		return &Event{AmountIn: amountIn, Path: path, TokenIn: firstOf(path), TokenOut: lastOf(path)}, true, nil
+	}
+
+	return nil, false, nil
+}
+
+func firstOf(addrs []common.Address) common.Address {
+	if len(addrs) == 0 {
+		return common.Address{}
+	}
+	return addrs[0]
+}
+
+func lastOf(addrs []common.Address) common.Address {
+	if len(addrs) == 0 {
+		return common.Address{}
+	}
+	return addrs[len(addrs)-1]
+}
+
+func selectorHex(hexSel string) []byte {
+	b := common.FromHex("0x" + hexSel)
+	return b
+}
+
+func mustArguments(types ...string) abi.Arguments {
+	args := make(abi.Arguments, len(types))
+	for i, t := range types {
+		typ, err := abi.NewType(t, "", nil)
+		if err != nil {
+			panic(err)
+		}
+		args[i] = abi.Argument{Type: typ}
+	}
+	return args
+}
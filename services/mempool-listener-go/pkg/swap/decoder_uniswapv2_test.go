@@ -0,0 +1,124 @@
+package swap
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func legacyTxWithData(data []byte, value int64) *types.Transaction {
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    0,
+		GasPrice: big.NewInt(1),
+		Gas:      21000,
+		To:       &common.Address{},
+		Value:    big.NewInt(value),
+		Data:     data,
+	})
+}
+
+func TestUniswapV2DecodeSwapExactTokensForTokens(t *testing.T) {
+	tokenA := common.HexToAddress("0xA")
+	tokenB := common.HexToAddress("0xB")
+	path := []common.Address{tokenA, tokenB}
+
+	packed, err := v2ArgsWithAmountIn.Pack(
+		big.NewInt(1000), big.NewInt(900), path, common.HexToAddress("0xC"), big.NewInt(111),
+	)
+	if err != nil {
+		t.Fatalf("packing args: %v", err)
+	}
+	data := append(append([]byte{}, selV2SwapExactTokensForTokens...), packed...)
+
+	ev, ok, err := UniswapV2Decoder{}.Decode(legacyTxWithData(data, 0), common.Address{})
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	if !ok {
+		t.Fatal("Decode did not recognize swapExactTokensForTokens selector")
+	}
+	if ev.AmountIn.Cmp(big.NewInt(1000)) != 0 {
+		t.Fatalf("AmountIn = %s, want 1000", ev.AmountIn)
+	}
+	if ev.TokenIn != tokenA || ev.TokenOut != tokenB {
+		t.Fatalf("TokenIn/TokenOut = %s/%s, want %s/%s", ev.TokenIn, ev.TokenOut, tokenA, tokenB)
+	}
+	if len(ev.Path) != 2 {
+		t.Fatalf("len(Path) = %d, want 2", len(ev.Path))
+	}
+}
+
+func TestUniswapV2DecodeSwapTokensForExactTokensUsesAmountInMax(t *testing.T) {
+	tokenA := common.HexToAddress("0xA")
+	tokenB := common.HexToAddress("0xB")
+	path := []common.Address{tokenA, tokenB}
+
+	packed, err := v2ArgsWithAmountIn.Pack(
+		big.NewInt(500), big.NewInt(600), path, common.HexToAddress("0xC"), big.NewInt(111),
+	)
+	if err != nil {
+		t.Fatalf("packing args: %v", err)
+	}
+	data := append(append([]byte{}, selV2SwapTokensForExactTokens...), packed...)
+
+	ev, ok, err := UniswapV2Decoder{}.Decode(legacyTxWithData(data, 0), common.Address{})
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	if !ok {
+		t.Fatal("Decode did not recognize swapTokensForExactTokens selector")
+	}
+	// The second arg (amountInMax) is the best available estimate for this
+	// selector, not the first (amountOut).
+	if ev.AmountIn.Cmp(big.NewInt(600)) != 0 {
+		t.Fatalf("AmountIn = %s, want 600 (amountInMax)", ev.AmountIn)
+	}
+	// amountOut is exact for this selector, unlike amountIn, so it should
+	// still be captured.
+	if ev.AmountOut == nil || ev.AmountOut.Cmp(big.NewInt(500)) != 0 {
+		t.Fatalf("AmountOut = %v, want 500", ev.AmountOut)
+	}
+}
+
+func TestUniswapV2DecodeSwapExactETHForTokensUsesTxValue(t *testing.T) {
+	tokenA := common.HexToAddress("0xA")
+	tokenB := common.HexToAddress("0xB")
+	path := []common.Address{tokenA, tokenB}
+
+	packed, err := v2ArgsNoAmountIn.Pack(big.NewInt(900), path, common.HexToAddress("0xC"), big.NewInt(111))
+	if err != nil {
+		t.Fatalf("packing args: %v", err)
+	}
+	data := append(append([]byte{}, selV2SwapExactETHForTokens...), packed...)
+
+	ev, ok, err := UniswapV2Decoder{}.Decode(legacyTxWithData(data, 42), common.Address{})
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	if !ok {
+		t.Fatal("Decode did not recognize swapExactETHForTokens selector")
+	}
+	if ev.AmountIn.Cmp(big.NewInt(42)) != 0 {
+		t.Fatalf("AmountIn = %s, want tx.Value() = 42", ev.AmountIn)
+	}
+	if ev.TokenIn != tokenA || ev.TokenOut != tokenB {
+		t.Fatalf("TokenIn/TokenOut = %s/%s, want %s/%s", ev.TokenIn, ev.TokenOut, tokenA, tokenB)
+	}
+}
+
+func TestUniswapV2DecodeUnrecognizedSelectorReturnsNotOK(t *testing.T) {
+	data := append([]byte{0xde, 0xad, 0xbe, 0xef}, make([]byte, 64)...)
+
+	ev, ok, err := UniswapV2Decoder{}.Decode(legacyTxWithData(data, 0), common.Address{})
+	if err != nil {
+		t.Fatalf("Decode returned error for an unrecognized selector: %v", err)
+	}
+	if ok {
+		t.Fatal("Decode should not recognize an unrelated selector")
+	}
+	if ev != nil {
+		t.Fatalf("ev = %v, want nil when ok=false", ev)
+	}
+}
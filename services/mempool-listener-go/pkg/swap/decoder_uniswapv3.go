@@ -0,0 +1,129 @@
+package swap
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// UniswapV3Decoder recognizes the SwapRouter entrypoints used for
+// single-hop and multi-hop exact-input swaps. V3 calldata is decoded by
+// hand rather than via abi.Arguments: the router's ExactInput struct embeds
+// a dynamic `path bytes` field, and go-ethereum's reflection-based tuple
+// unpacking doesn't give us a convenient way to read it back out.
+type UniswapV3Decoder struct{}
+
+func (UniswapV3Decoder) Name() string { return "uniswap-v3" }
+
+var (
+	// exactInputSingle((address,address,uint24,address,uint256,uint256,uint256,uint160))
+	selV3ExactInputSingle = selectorHex("414bf389")
+	// exactInput((bytes,address,uint256,uint256,uint256))
+	selV3ExactInput = selectorHex("c04b8d59")
+)
+
+// v3PathHopSize is the byte width of one (address, fee) hop packed into a
+// V3 multi-hop path: a 20-byte token address followed by a 3-byte fee tier.
+const v3PathHopSize = 23
+
+func (UniswapV3Decoder) Decode(tx *types.Transaction, _ common.Address) (*Event, bool, error) {
+	sel := selector(tx)
+	if sel == nil {
+		return nil, false, nil
+	}
+	data := tx.Data()[4:]
+
+	switch {
+	case bytes.Equal(sel, selV3ExactInputSingle):
+		// Sole argument is a static tuple, so it's encoded inline with no
+		// leading offset word: tokenIn, tokenOut, fee, recipient, deadline,
+		// amountIn, amountOutMinimum, sqrtPriceLimitX96.
+		word, err := wordAt(data, 0, 8)
+		if err != nil {
+			return nil, false, err
+		}
+		tokenIn := common.BytesToAddress(word(0))
+		tokenOut := common.BytesToAddress(word(1))
+		amountIn := new(big.Int).SetBytes(word(5))
+		return &Event{TokenIn: tokenIn, TokenOut: tokenOut, AmountIn: amountIn, Path: []common.Address{tokenIn, tokenOut}}, true, nil
+
+	case bytes.Equal(sel, selV3ExactInput):
+		// Sole argument is a dynamic tuple: one offset word pointing at the
+		// tuple's own head (path offset, recipient, deadline, amountIn,
+		// amountOutMinimum) followed by the path's length-prefixed bytes.
+		outerWord, err := wordAt(data, 0, 1)
+		if err != nil {
+			return nil, false, err
+		}
+		tupleStartBig := new(big.Int).SetBytes(outerWord(0))
+		tupleStart, ok := safeOffset(tupleStartBig, len(data))
+		if !ok {
+			return nil, false, fmt.Errorf("exactInput: tuple offset %s out of range", tupleStartBig)
+		}
+		tuple := data[tupleStart:]
+
+		tw, err := wordAt(tuple, 0, 4)
+		if err != nil {
+			return nil, false, err
+		}
+		pathOffsetBig := new(big.Int).SetBytes(tw(0))
+		amountIn := new(big.Int).SetBytes(tw(3))
+
+		pathOffset, ok := safeOffset(pathOffsetBig, len(tuple))
+		if !ok || len(tuple) < pathOffset+32 {
+			return nil, false, fmt.Errorf("exactInput: path offset %s out of range", pathOffsetBig)
+		}
+		pathLenBig := new(big.Int).SetBytes(tuple[pathOffset : pathOffset+32])
+		pathLen, ok := safeOffset(pathLenBig, len(tuple))
+		pathStart := pathOffset + 32
+		if !ok || pathLen < v3PathHopSize || len(tuple) < pathStart+pathLen {
+			return nil, false, fmt.Errorf("exactInput: path length %s out of range", pathLenBig)
+		}
+		path := tuple[pathStart : pathStart+pathLen]
+		tokenIn := common.BytesToAddress(path[:20])
+		tokenOut := common.BytesToAddress(path[len(path)-20:])
+		return &Event{TokenIn: tokenIn, TokenOut: tokenOut, AmountIn: amountIn, Path: decodeV3Path(path)}, true, nil
+	}
+
+	return nil, false, nil
+}
+
+// decodeV3Path unpacks a packed (address, fee, address, fee, ..., address)
+// V3 path into the list of tokens it routes through.
+func decodeV3Path(path []byte) []common.Address {
+	var tokens []common.Address
+	for off := 0; off+20 <= len(path); off += v3PathHopSize {
+		tokens = append(tokens, common.BytesToAddress(path[off:off+20]))
+	}
+	return tokens
+}
+
+// safeOffset converts v to an int usable as a slice index, reporting false
+// if v is negative or exceeds limit. big.Int.Int64() is documented as
+// producing an undefined result (in practice, a truncated and possibly
+// negative int64) when v doesn't fit in 64 bits, so attacker-controlled
+// 256-bit calldata words must be range-checked in big.Int space before
+// they're ever narrowed to an int; narrowing first and checking after lets
+// a crafted offset wrap around any bound.
+func safeOffset(v *big.Int, limit int) (int, bool) {
+	if v.Sign() < 0 || v.Cmp(big.NewInt(int64(limit))) > 0 {
+		return 0, false
+	}
+	return int(v.Int64()), true
+}
+
+// wordAt returns a function that reads the i-th 32-byte word from data
+// (0-indexed), after verifying data is long enough to hold `count` words.
+func wordAt(data []byte, start, count int) (func(i int) []byte, error) {
+	need := (start + count) * 32
+	if len(data) < need {
+		return nil, fmt.Errorf("calldata too short: need %d bytes, have %d", need, len(data))
+	}
+	return func(i int) []byte {
+		off := (start + i) * 32
+		return data[off : off+32]
+	}, nil
+}
@@ -0,0 +1,30 @@
+// Package swap decodes pending DEX-swap transactions into a common,
+// protocol-agnostic event shape so downstream strategies don't need to know
+// which router produced them.
+package swap
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Event is the normalized representation of a decoded (or blob-carrying)
+// pending transaction, as returned by a Decoder's Decode or by
+// DecodeBlobTx.
+type Event struct {
+	TxHash   common.Hash
+	Protocol string // e.g. "uniswap-v2", "uniswap-v3", "1inch", "curve", "blob"
+	Sender   common.Address
+	GasPrice *big.Int
+
+	// Swap fields. Left zero-valued for non-swap events (e.g. blob txs).
+	TokenIn   common.Address
+	TokenOut  common.Address
+	AmountIn  *big.Int
+	AmountOut *big.Int
+	Path      []common.Address
+
+	// Blob fields. Only populated when Protocol == "blob".
+	BlobVersionedHashes []common.Hash
+}